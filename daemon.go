@@ -0,0 +1,146 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/eventlog"
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/fetcher"
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/notify"
+)
+
+const defaultPollInterval = 15 * time.Minute
+
+// jitterFraction is how much a run's actual delay may vary from its
+// interval, to avoid many same-interval sources hammering the scheduler (and
+// their endpoints) in lockstep.
+const jitterFraction = 0.1
+
+// runDaemon keeps the process alive, polling each source on its own
+// interval via a jittered heap-based scheduler, until SIGINT/SIGTERM. It
+// also serves /healthz and /metrics and appends every diff to an event log.
+func runDaemon(manifest *Manifest, f *fetcher.Fetcher, g notify.Globals, defaultChatID string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	defaultInterval := defaultPollInterval
+	if raw := os.Getenv("DEFAULT_INTERVAL"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			defaultInterval = d
+		}
+	}
+
+	registry := newStatusRegistry(manifest.Sources)
+	events := eventlog.Open(envOr("EVENT_LOG", "events.jsonl"))
+
+	srv := newStatusServer(registry)
+	srv.Addr = envOr("HEALTH_ADDR", ":8080")
+	go func() {
+		log.Printf("daemon: serving /healthz and /metrics on %s", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("daemon: http server: %v", err)
+		}
+	}()
+
+	queue := newRunQueue(manifest.Sources)
+
+	for {
+		if queue.Len() == 0 {
+			return nil
+		}
+		next := queue.peek()
+
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			srv.Shutdown(shutdownCtx)
+			return nil
+		case <-time.After(time.Until(next.at)):
+		}
+
+		due := heap.Pop(queue).(*scheduledRun)
+		interval := due.src.pollInterval(defaultInterval)
+		heap.Push(queue, &scheduledRun{at: time.Now().Add(withJitter(interval)), src: due.src})
+
+		go runAndRecord(ctx, f, g, defaultChatID, due.src, registry, events)
+	}
+}
+
+// runAndRecord runs one source through the normal runSource pipeline and
+// records the outcome in the status registry and event log.
+func runAndRecord(ctx context.Context, f *fetcher.Fetcher, g notify.Globals, defaultChatID string, src Source, registry *statusRegistry, events *eventlog.Log) {
+	result, err := runSource(ctx, f, g, defaultChatID, src)
+	registry.record(src, result, err)
+
+	event := eventlog.Event{Time: time.Now(), Label: src.Label, Endpoint: src.Endpoint}
+	if err != nil {
+		event.Error = err.Error()
+	} else if result.Changed {
+		event.Added, event.Removed = result.Added, result.Removed
+	} else {
+		return // no change, no error: nothing worth logging
+	}
+	if err := events.Append(event); err != nil {
+		log.Printf("[%s] append event log: %v", src.Label, err)
+	}
+}
+
+func withJitter(d time.Duration) time.Duration {
+	spread := time.Duration(float64(d) * jitterFraction)
+	if spread <= 0 {
+		return d
+	}
+	return d - spread + time.Duration(rand.Int63n(int64(2*spread)))
+}
+
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// scheduledRun is one entry in the scheduler's heap: src is next due to run
+// at time at.
+type scheduledRun struct {
+	at  time.Time
+	src Source
+}
+
+// runQueue is a min-heap of scheduledRuns ordered by at, implementing
+// container/heap.Interface.
+type runQueue []*scheduledRun
+
+func newRunQueue(sources []Source) *runQueue {
+	q := make(runQueue, 0, len(sources))
+	now := time.Now()
+	for i, src := range sources {
+		// Stagger initial runs slightly so a large manifest doesn't fire
+		// every source at once on startup.
+		q = append(q, &scheduledRun{at: now.Add(time.Duration(i) * 250 * time.Millisecond), src: src})
+	}
+	heap.Init(&q)
+	return &q
+}
+
+func (q runQueue) peek() *scheduledRun { return q[0] }
+
+func (q runQueue) Len() int            { return len(q) }
+func (q runQueue) Less(i, j int) bool  { return q[i].at.Before(q[j].at) }
+func (q runQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *runQueue) Push(x any)         { *q = append(*q, x.(*scheduledRun)) }
+func (q *runQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// newStatusServer builds the daemon's /healthz and /metrics endpoints on
+// top of a statusRegistry.
+func newStatusServer(registry *statusRegistry) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"status": "ok", "sources": registry.snapshot()})
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeMetrics(w, registry.snapshot())
+	})
+	return &http.Server{Handler: mux}
+}
+
+// writeMetrics renders statuses in the Prometheus text format: last-run time
+// and last-run error as gauges, the change count as a counter.
+func writeMetrics(w http.ResponseWriter, statuses []sourceStatus) {
+	fmt.Fprintln(w, "# HELP json_state_tracker_last_run_timestamp_seconds Unix time of the source's last poll.")
+	fmt.Fprintln(w, "# TYPE json_state_tracker_last_run_timestamp_seconds gauge")
+	for _, s := range statuses {
+		fmt.Fprintf(w, "json_state_tracker_last_run_timestamp_seconds{label=%q} %d\n", s.Label, s.LastRun.Unix())
+	}
+
+	fmt.Fprintln(w, "# HELP json_state_tracker_change_count_total Number of detected changes since the daemon started.")
+	fmt.Fprintln(w, "# TYPE json_state_tracker_change_count_total counter")
+	for _, s := range statuses {
+		fmt.Fprintf(w, "json_state_tracker_change_count_total{label=%q} %d\n", s.Label, s.ChangeCount)
+	}
+
+	fmt.Fprintln(w, "# HELP json_state_tracker_last_run_error Whether the source's last poll failed (1) or not (0).")
+	fmt.Fprintln(w, "# TYPE json_state_tracker_last_run_error gauge")
+	for _, s := range statuses {
+		errVal := 0
+		if s.LastError != "" {
+			errVal = 1
+		}
+		fmt.Fprintf(w, "json_state_tracker_last_run_error{label=%q,error=%q} %d\n", s.Label, strings.ReplaceAll(s.LastError, "\n", " "), errVal)
+	}
+}
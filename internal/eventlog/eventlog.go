@@ -0,0 +1,53 @@
+// Package eventlog appends a rolling history of diff events to a JSON-lines
+// file so users can reconstruct what changed and when without relying on
+// notification scrollback.
+package eventlog
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// Event is one line of the log.
+type Event struct {
+	Time     time.Time `json:"time"`
+	Label    string    `json:"label"`
+	Endpoint string    `json:"endpoint"`
+	Added    []string  `json:"added,omitempty"`
+	Removed  []string  `json:"removed,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Log appends Events to a single file, serializing writers so concurrent
+// sources don't interleave lines.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Open returns a Log that appends to path, creating it if necessary.
+func Open(path string) *Log {
+	return &Log{path: path}
+}
+
+// Append writes e as one JSON line.
+func (l *Log) Append(e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(data)
+	return err
+}
@@ -0,0 +1,63 @@
+package extract
+
+import "sort"
+
+// FieldChange is one field that differs between the old and new version of
+// a record with the same key.
+type FieldChange struct {
+	Key    string
+	Field  string
+	Before string
+	After  string
+}
+
+// Diff compares two record sets keyed by schema, reporting records that
+// appeared, disappeared, or had at least one field change.
+func Diff(schema Schema, oldRecords, newRecords []Record) (added, removed []Record, modified []FieldChange) {
+	oldByKey := make(map[string]Record, len(oldRecords))
+	for _, r := range oldRecords {
+		oldByKey[schema.KeyOf(r)] = r
+	}
+	newByKey := make(map[string]Record, len(newRecords))
+	for _, r := range newRecords {
+		newByKey[schema.KeyOf(r)] = r
+	}
+
+	var addedKeys, removedKeys, commonKeys []string
+	for k := range newByKey {
+		if _, ok := oldByKey[k]; ok {
+			commonKeys = append(commonKeys, k)
+		} else {
+			addedKeys = append(addedKeys, k)
+		}
+	}
+	for k := range oldByKey {
+		if _, ok := newByKey[k]; !ok {
+			removedKeys = append(removedKeys, k)
+		}
+	}
+	sort.Strings(addedKeys)
+	sort.Strings(removedKeys)
+	sort.Strings(commonKeys)
+
+	for _, k := range addedKeys {
+		added = append(added, newByKey[k])
+	}
+	for _, k := range removedKeys {
+		removed = append(removed, oldByKey[k])
+	}
+	for _, k := range commonKeys {
+		oldRec, newRec := oldByKey[k], newByKey[k]
+		var fields []string
+		for field := range newRec {
+			if oldRec[field] != newRec[field] {
+				fields = append(fields, field)
+			}
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			modified = append(modified, FieldChange{Key: k, Field: field, Before: oldRec[field], After: newRec[field]})
+		}
+	}
+	return added, removed, modified
+}
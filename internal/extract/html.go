@@ -0,0 +1,69 @@
+package extract
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// HTML extracts records from an HTML response body per schema. Parent
+// selects each record's container element; each Fields entry is a CSS
+// selector relative to that container (optionally suffixed with "@attr"),
+// or empty to read the container's own text/attribute directly (the legacy
+// single-value mode).
+func HTML(data []byte, schema Schema) ([]Record, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML: %w", err)
+	}
+
+	var records []Record
+	doc.Find(schema.Parent).Each(func(_ int, container *goquery.Selection) {
+		rec := make(Record, len(schema.Fields))
+		for field, path := range schema.Fields {
+			rec[field] = strings.TrimSpace(extractHTMLField(container, path))
+		}
+		if isLegacySchema(schema) && rec[legacyField] == "" {
+			// Matches the original extractSetHTML, which never added empty
+			// or whitespace-only matches to the tracked set.
+			return
+		}
+		records = append(records, rec)
+	})
+	return records, nil
+}
+
+// isLegacySchema reports whether schema is the implicit single-"value"-field
+// schema built from a bare TRACK_PATH, as opposed to a user-defined
+// multi-field schema where an empty field is a legitimate value.
+func isLegacySchema(schema Schema) bool {
+	return schema.Key == legacyField && len(schema.Fields) == 1
+}
+
+// extractHTMLField reads one field's value out of container. An empty path
+// reads the container itself; otherwise path is a CSS selector optionally
+// suffixed with "@attr".
+func extractHTMLField(container *goquery.Selection, path string) string {
+	selector, attr := splitAttr(path)
+
+	target := container
+	if selector != "" {
+		target = container.Find(selector).First()
+	}
+	if attr != "" {
+		val, _ := target.Attr(attr)
+		return val
+	}
+	return target.Text()
+}
+
+// splitAttr splits a "selector@attr" path into its selector and attribute
+// name. If there is no @attr suffix, attr is empty.
+func splitAttr(path string) (selector, attr string) {
+	if i := strings.LastIndex(path, "@"); i != -1 {
+		return path[:i], path[i+1:]
+	}
+	return path, ""
+}
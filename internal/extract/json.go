@@ -0,0 +1,25 @@
+package extract
+
+import "github.com/tidwall/gjson"
+
+// JSON extracts records from a JSON response body per schema. Root selects
+// the array of items; each Fields entry is a gjson path relative to the
+// item, or "" to use the item itself (the legacy single-value mode).
+func JSON(data []byte, schema Schema) []Record {
+	items := gjson.GetBytes(data, schema.Root)
+
+	var records []Record
+	items.ForEach(func(_, item gjson.Result) bool {
+		rec := make(Record, len(schema.Fields))
+		for field, path := range schema.Fields {
+			if path == "" {
+				rec[field] = item.String()
+				continue
+			}
+			rec[field] = item.Get(path).String()
+		}
+		records = append(records, rec)
+		return true
+	})
+	return records
+}
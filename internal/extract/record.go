@@ -0,0 +1,39 @@
+package extract
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"sort"
+)
+
+// Record is one extracted item: field name to value.
+type Record map[string]string
+
+// KeyOf returns the record's identity under schema: the configured key
+// field if set and present, otherwise a hash of every field so the record
+// is only considered unchanged if all its fields match exactly.
+func (s Schema) KeyOf(r Record) string {
+	if s.Key != "" {
+		if v, ok := r[s.Key]; ok {
+			return v
+		}
+	}
+	return hashRecord(r)
+}
+
+func hashRecord(r Record) string {
+	names := make([]string, 0, len(r))
+	for k := range r {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	h := sha1.New()
+	for _, k := range names {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(r[k]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,47 @@
+// Package extract pulls structured records out of a fetched response,
+// generalizing the tracker's original flat string-set extraction to
+// multi-field records that can be diffed field-by-field.
+package extract
+
+// Schema describes how to turn a response body into a list of Records.
+//
+// For JSON sources, Root is a gjson path to the array of items, and each
+// entry in Fields is a gjson path (relative to the item) for that field.
+// For HTML sources, Parent is a CSS selector matching each record's
+// container element, and each entry in Fields is a CSS selector relative to
+// the container, optionally suffixed with "@attr" to read an attribute
+// instead of text content (same convention as the legacy TRACK_PATH).
+//
+// Key names the field that uniquely identifies a record across runs (e.g.
+// a SKU). If empty, records are keyed by a hash of all their field values,
+// which means any field change is seen as the old record disappearing and
+// a new one appearing rather than a modification.
+type Schema struct {
+	Key    string            `json:"key,omitempty" yaml:"key,omitempty"`
+	Root   string            `json:"root,omitempty" yaml:"root,omitempty"`
+	Parent string            `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Fields map[string]string `json:"fields" yaml:"fields"`
+}
+
+// legacyField is the implicit field name used when a bare selector/path is
+// given instead of a full schema, matching the tracker's original
+// flat-value behavior.
+const legacyField = "value"
+
+// NewLegacyJSONSchema builds the schema equivalent of the original
+// extractSetJSON: path is a gjson path to an array of scalar values, each
+// becoming a record with a single "value" field.
+func NewLegacyJSONSchema(path string) Schema {
+	return Schema{Root: path, Key: legacyField, Fields: map[string]string{legacyField: ""}}
+}
+
+// NewLegacyHTMLSchema builds the schema equivalent of the original
+// extractSetHTML: selector matches each element directly, and attr, if
+// non-empty, reads that attribute off the same element instead of its text.
+func NewLegacyHTMLSchema(selector, attr string) Schema {
+	fieldPath := ""
+	if attr != "" {
+		fieldPath = "@" + attr
+	}
+	return Schema{Parent: selector, Key: legacyField, Fields: map[string]string{legacyField: fieldPath}}
+}
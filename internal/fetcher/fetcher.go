@@ -0,0 +1,123 @@
+// Package fetcher wraps net/http with the behaviors the tracker needs from
+// every request: a context-bound timeout, conditional GET against a
+// previously-seen ETag/Last-Modified pair, and bounded retries with
+// exponential backoff on transient failures.
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	maxRetries  = 3
+	baseBackoff = 500 * time.Millisecond
+	backoffMul  = 2
+)
+
+// CacheMeta holds the conditional-GET validators for a single endpoint,
+// persisted alongside its state file so the next run can send
+// If-None-Match / If-Modified-Since.
+type CacheMeta struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// Fetcher performs HTTP GETs with a shared timeout and retry policy.
+type Fetcher struct {
+	Client  *http.Client
+	Timeout time.Duration
+}
+
+// New returns a Fetcher with the tracker's default per-request timeout.
+func New() *Fetcher {
+	return &Fetcher{
+		Client:  &http.Client{},
+		Timeout: 10 * time.Second,
+	}
+}
+
+// Do fetches endpoint with the given headers and cache validators. If the
+// server responds 304 Not Modified, notModified is true and body/contentType
+// are empty; callers should reuse their previously saved set rather than
+// re-parsing. 5xx responses and network errors are retried with exponential
+// backoff and full jitter before giving up; 4xx responses are returned
+// immediately since retrying them cannot help.
+func (f *Fetcher) Do(ctx context.Context, endpoint string, headers map[string]string, cache CacheMeta) (body []byte, contentType string, notModified bool, newCache CacheMeta, err error) {
+	backoff := baseBackoff
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		body, contentType, notModified, newCache, retry, err = f.doOnce(ctx, endpoint, headers, cache)
+		if err == nil || !retry || attempt >= maxRetries-1 {
+			return body, contentType, notModified, newCache, err
+		}
+
+		sleep := time.Duration(rand.Int63n(int64(backoff)))
+		select {
+		case <-ctx.Done():
+			return nil, "", false, CacheMeta{}, ctx.Err()
+		case <-time.After(sleep):
+		}
+		backoff *= backoffMul
+	}
+}
+
+// doOnce performs a single attempt. retry reports whether the error, if any,
+// is worth retrying (network failures and 5xx responses).
+func (f *Fetcher) doOnce(ctx context.Context, endpoint string, headers map[string]string, cache CacheMeta) (body []byte, contentType string, notModified bool, newCache CacheMeta, retry bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, f.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, "", false, CacheMeta{}, false, fmt.Errorf("build request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if cache.ETag != "" {
+		req.Header.Set("If-None-Match", cache.ETag)
+	}
+	if cache.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cache.LastModified)
+	}
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, "", false, CacheMeta{}, true, fmt.Errorf("request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	newCache = CacheMeta{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		io.Copy(io.Discard, resp.Body)
+		// A 304 commonly omits one or both validators rather than echoing
+		// them back; fall back to what we already had so the next request
+		// still has something to send as If-None-Match/If-Modified-Since.
+		if newCache.ETag == "" {
+			newCache.ETag = cache.ETag
+		}
+		if newCache.LastModified == "" {
+			newCache.LastModified = cache.LastModified
+		}
+		return nil, "", true, newCache, false, nil
+	}
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", false, CacheMeta{}, true, fmt.Errorf("read body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, "", false, CacheMeta{}, resp.StatusCode >= 500, err
+	}
+	return body, resp.Header.Get("Content-Type"), false, newCache, false, nil
+}
@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/smtp"
+)
+
+// Config describes one entry in a source's notifier chain. Which fields
+// matter depends on Type.
+type Config struct {
+	Type string `json:"type" yaml:"type"` // "telegram" (default), "webhook", "slack", "email"
+
+	ChatID string   `json:"chat_id,omitempty" yaml:"chat_id,omitempty"` // telegram
+	URL    string   `json:"url,omitempty" yaml:"url,omitempty"`         // webhook, slack
+	To     []string `json:"to,omitempty" yaml:"to,omitempty"`           // email
+}
+
+// Globals holds the secrets/settings shared by every notifier of a given
+// type, regardless of which source they're attached to.
+type Globals struct {
+	TelegramToken string
+
+	SMTPAddr string
+	SMTPFrom string
+	SMTPAuth smtp.Auth
+}
+
+// Build constructs the Notifier described by cfg.
+func Build(cfg Config, g Globals) (Notifier, error) {
+	switch cfg.Type {
+	case "", "telegram":
+		if cfg.ChatID == "" {
+			return nil, fmt.Errorf("telegram notifier: chat_id is required")
+		}
+		if g.TelegramToken == "" {
+			return nil, fmt.Errorf("telegram notifier: no bot token configured")
+		}
+		return NewTelegram(g.TelegramToken, cfg.ChatID), nil
+	case "webhook":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("webhook notifier: url is required")
+		}
+		return NewWebhook(cfg.URL), nil
+	case "slack":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("slack notifier: url is required")
+		}
+		return NewSlack(cfg.URL), nil
+	case "email":
+		if len(cfg.To) == 0 {
+			return nil, fmt.Errorf("email notifier: to is required")
+		}
+		if g.SMTPAddr == "" {
+			return nil, fmt.Errorf("email notifier: no SMTP server configured")
+		}
+		return NewEmail(g.SMTPAddr, g.SMTPFrom, cfg.To, g.SMTPAuth), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type %q", cfg.Type)
+	}
+}
+
+// httpClient is shared by the webhook and Slack notifiers.
+var httpClient = &http.Client{}
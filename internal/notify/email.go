@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"net/smtp"
+	"strings"
+)
+
+// Email delivers changes as an HTML email over SMTP.
+type Email struct {
+	Addr string // SMTP server address, e.g. "smtp.example.com:587"
+	From string
+	To   []string
+	Auth smtp.Auth
+}
+
+// NewEmail returns an Email notifier sending through the given SMTP server.
+func NewEmail(addr, from string, to []string, auth smtp.Auth) *Email {
+	return &Email{Addr: addr, From: from, To: to, Auth: auth}
+}
+
+func (e *Email) Send(_ context.Context, c Change) error {
+	subject := fmt.Sprintf("%s state changed", c.Label)
+	if c.Note != "" {
+		subject = fmt.Sprintf("%s: %s", c.Label, c.Note)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprint(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprint(&msg, "Content-Type: text/html; charset=\"UTF-8\"\r\n\r\n")
+	msg.WriteString(renderEmailHTML(c))
+
+	return smtp.SendMail(e.Addr, e.Auth, e.From, e.To, msg.Bytes())
+}
+
+func renderEmailHTML(c Change) string {
+	var buf strings.Builder
+	buf.WriteString("<html><body>\n")
+	if c.Note != "" {
+		fmt.Fprintf(&buf, "<p><b>%s</b>: %s</p>\n", html.EscapeString(c.Label), html.EscapeString(c.Note))
+		buf.WriteString("</body></html>")
+		return buf.String()
+	}
+
+	fmt.Fprintf(&buf, "<p><b>%s</b> state changed: %s</p>\n", html.EscapeString(c.Label), html.EscapeString(c.Endpoint))
+	writeEmailList(&buf, fmt.Sprintf("Added (%d)", len(c.Added)), c.Added)
+	writeEmailList(&buf, fmt.Sprintf("Removed (%d)", len(c.Removed)), c.Removed)
+	if len(c.Modified) > 0 {
+		fmt.Fprintf(&buf, "<p><b>Modified (%d):</b></p>\n<table border=\"1\" cellpadding=\"4\">\n", len(c.Modified))
+		fmt.Fprint(&buf, "<tr><th>Key</th><th>Field</th><th>Before</th><th>After</th></tr>\n")
+		for _, fc := range c.Modified {
+			fmt.Fprintf(&buf, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(fc.Key), html.EscapeString(fc.Field), html.EscapeString(fc.Before), html.EscapeString(fc.After))
+		}
+		buf.WriteString("</table>\n")
+	}
+	buf.WriteString("</body></html>")
+	return buf.String()
+}
+
+func writeEmailList(buf *strings.Builder, heading string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "<p><b>%s:</b></p>\n<ul>\n", html.EscapeString(heading))
+	for _, v := range values {
+		fmt.Fprintf(buf, "<li>%s</li>\n", html.EscapeString(v))
+	}
+	buf.WriteString("</ul>\n")
+}
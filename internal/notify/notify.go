@@ -0,0 +1,55 @@
+// Package notify renders and delivers tracker notifications. A Change is
+// the structured description of what happened to a source; Notifiers
+// render it however fits their backend (Telegram MarkdownV2, Slack Block
+// Kit, an HTML email, or raw JSON to a generic webhook).
+package notify
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Change describes a single notification-worthy event for a source.
+type Change struct {
+	Label    string
+	Endpoint string
+	Added    []string
+	Removed  []string
+	// Modified holds per-field before/after values for structured (schema
+	// based) sources, where a record can change without being added or
+	// removed. Flat-value sources never populate this.
+	Modified []FieldChange
+	// Note, when set, is a plain informational message (e.g. "first run,
+	// saving baseline" or a fetch error) rendered instead of Added/Removed.
+	Note      string
+	Timestamp time.Time
+}
+
+// FieldChange is one field that differs between the old and new version of
+// a record sharing the same key (e.g. "price changed for SKU-123").
+type FieldChange struct {
+	Key    string
+	Field  string
+	Before string
+	After  string
+}
+
+// Notifier delivers a Change to some destination.
+type Notifier interface {
+	Send(ctx context.Context, c Change) error
+}
+
+// Chain fans a Change out to every Notifier in it, running each regardless
+// of whether an earlier one failed, and returns their combined errors.
+type Chain []Notifier
+
+func (c Chain) Send(ctx context.Context, change Change) error {
+	var errs []error
+	for _, n := range c {
+		if err := n.Send(ctx, change); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
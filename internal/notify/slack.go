@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Slack delivers changes to a Slack (or Mattermost-compatible) incoming
+// webhook, rendered as Block Kit.
+type Slack struct {
+	WebhookURL string
+}
+
+// NewSlack returns a Slack notifier posting to webhookURL.
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{WebhookURL: webhookURL}
+}
+
+type slackPayload struct {
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (s *Slack) Send(ctx context.Context, c Change) error {
+	var body strings.Builder
+	if c.Note != "" {
+		fmt.Fprintf(&body, "*%s*: %s", c.Label, c.Note)
+	} else {
+		fmt.Fprintf(&body, "*%s* state changed: %s", c.Label, c.Endpoint)
+		if len(c.Added) > 0 {
+			fmt.Fprintf(&body, "\n*Added (%d):*\n%s", len(c.Added), strings.Join(c.Added, "\n"))
+		}
+		if len(c.Removed) > 0 {
+			fmt.Fprintf(&body, "\n*Removed (%d):*\n%s", len(c.Removed), strings.Join(c.Removed, "\n"))
+		}
+		if len(c.Modified) > 0 {
+			lines := make([]string, len(c.Modified))
+			for i, fc := range c.Modified {
+				lines[i] = fmt.Sprintf("%s changed for %s: %s → %s", fc.Field, fc.Key, fc.Before, fc.After)
+			}
+			fmt.Fprintf(&body, "\n*Modified (%d):*\n%s", len(c.Modified), strings.Join(lines, "\n"))
+		}
+	}
+
+	payload := slackPayload{Blocks: []slackBlock{{
+		Type: "section",
+		Text: &slackText{Type: "mrkdwn", Text: body.String()},
+	}}}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("slack: marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.WebhookURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("slack HTTP %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// telegramMsgLimit is Telegram's hard cap on a single sendMessage text.
+const telegramMsgLimit = 4000
+
+// telegramMaxChunks is how many split messages we'll send before giving up
+// and attaching the full diff as a file instead.
+const telegramMaxChunks = 3
+
+// Telegram delivers changes to a Telegram chat via the bot API, formatted
+// as MarkdownV2.
+type Telegram struct {
+	Token  string
+	ChatID string
+}
+
+// NewTelegram returns a Telegram notifier for the given bot token and chat.
+func NewTelegram(token, chatID string) *Telegram {
+	return &Telegram{Token: token, ChatID: chatID}
+}
+
+func (t *Telegram) Send(ctx context.Context, c Change) error {
+	msg := formatTelegramMarkdown(c)
+	if len(msg) <= telegramMsgLimit {
+		return t.sendMessage(ctx, msg)
+	}
+
+	chunks := splitMessage(msg, telegramMsgLimit)
+	if len(chunks) > telegramMaxChunks {
+		return t.sendDocument(ctx, fmt.Sprintf("%s-diff.txt", c.Label), msg)
+	}
+	for _, chunk := range chunks {
+		if err := t.sendMessage(ctx, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Telegram) sendMessage(ctx context.Context, text string) error {
+	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.Token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(url.Values{
+		"chat_id":    {t.ChatID},
+		"text":       {text},
+		"parse_mode": {"MarkdownV2"},
+	}.Encode()))
+	if err != nil {
+		return fmt.Errorf("telegram request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("telegram request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("telegram HTTP %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (t *Telegram) sendDocument(ctx context.Context, filename, contents string) error {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.WriteField("chat_id", t.ChatID); err != nil {
+		return err
+	}
+	part, err := w.CreateFormFile("document", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write([]byte(contents)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendDocument", t.Token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, &buf)
+	if err != nil {
+		return fmt.Errorf("telegram request: %w", err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("telegram request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("telegram HTTP %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// formatTelegramMarkdown renders c as MarkdownV2 text.
+func formatTelegramMarkdown(c Change) string {
+	var buf strings.Builder
+	if c.Note != "" {
+		fmt.Fprintf(&buf, "*%s*: %s\n", escapeMarkdownV2(c.Label), escapeMarkdownV2(c.Note))
+		return buf.String()
+	}
+
+	fmt.Fprintf(&buf, "*%s* state changed: %s\n", escapeMarkdownV2(c.Label), escapeMarkdownV2(c.Endpoint))
+	if len(c.Added) > 0 {
+		fmt.Fprintf(&buf, "\n*Added \\(%d\\):*\n", len(c.Added))
+		for _, v := range c.Added {
+			fmt.Fprintf(&buf, "  %s\n", escapeMarkdownV2(v))
+		}
+	}
+	if len(c.Removed) > 0 {
+		fmt.Fprintf(&buf, "\n*Removed \\(%d\\):*\n", len(c.Removed))
+		for _, v := range c.Removed {
+			fmt.Fprintf(&buf, "  %s\n", escapeMarkdownV2(v))
+		}
+	}
+	if len(c.Modified) > 0 {
+		fmt.Fprintf(&buf, "\n*Modified \\(%d\\):*\n", len(c.Modified))
+		for _, fc := range c.Modified {
+			fmt.Fprintf(&buf, "  %s changed for %s: %s → %s\n",
+				escapeMarkdownV2(fc.Field), escapeMarkdownV2(fc.Key), escapeMarkdownV2(fc.Before), escapeMarkdownV2(fc.After))
+		}
+	}
+	return buf.String()
+}
+
+// markdownV2Special lists the characters Telegram's MarkdownV2 parse mode
+// requires callers to escape outside of an entity.
+const markdownV2Special = "_*[]()~`>#+-=|{}.!"
+
+func escapeMarkdownV2(s string) string {
+	var buf strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(markdownV2Special, r) {
+			buf.WriteByte('\\')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+// splitMessage breaks msg into chunks no larger than limit, breaking only on
+// line boundaries. A single line longer than limit is not split: it becomes a
+// chunk of its own that exceeds limit.
+func splitMessage(msg string, limit int) []string {
+	var chunks []string
+	lines := strings.SplitAfter(msg, "\n")
+	var cur strings.Builder
+	for _, line := range lines {
+		if cur.Len()+len(line) > limit && cur.Len() > 0 {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+		}
+		cur.WriteString(line)
+	}
+	if cur.Len() > 0 {
+		chunks = append(chunks, cur.String())
+	}
+	return chunks
+}
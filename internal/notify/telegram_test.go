@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// markdownV2ReservedOutsideEscape reports whether s contains a reserved
+// MarkdownV2 character that isn't preceded by the escaping backslash, i.e.
+// text Telegram's sendMessage would reject under parse_mode=MarkdownV2. The
+// bare '*' characters formatTelegramMarkdown itself emits as bold-entity
+// delimiters are intentional and exempt; every other reserved character
+// must be escaped, whether it came from escapeMarkdownV2 or a literal in
+// the formatter.
+func markdownV2ReservedOutsideEscape(s string) bool {
+	runes := []rune(s)
+	for i, r := range runes {
+		if r == '*' || !strings.ContainsRune(markdownV2Special, r) {
+			continue
+		}
+		if i == 0 || runes[i-1] != '\\' {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFormatTelegramMarkdownEscapesChangeSections(t *testing.T) {
+	change := Change{
+		Label:    "Widget Price",
+		Endpoint: "https://example.com/widget",
+		Added:    []string{"SKU-42"},
+		Removed:  []string{"SKU-7"},
+		Modified: []FieldChange{
+			{Key: "SKU-123", Field: "price", Before: "19.99", After: "17.49"},
+		},
+		Timestamp: time.Time{},
+	}
+
+	msg := formatTelegramMarkdown(change)
+
+	if markdownV2ReservedOutsideEscape(msg) {
+		t.Fatalf("formatTelegramMarkdown produced invalid MarkdownV2 (unescaped reserved character):\n%s", msg)
+	}
+	for _, want := range []string{"Added \\(1\\)", "Removed \\(1\\)", "Modified \\(1\\)"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected message to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Webhook POSTs a Change as raw JSON to an arbitrary endpoint.
+type Webhook struct {
+	URL string
+}
+
+// NewWebhook returns a Webhook notifier posting to url.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{URL: url}
+}
+
+func (w *Webhook) Send(ctx context.Context, c Change) error {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("webhook: marshal change: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("webhook HTTP %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/extract"
+)
+
+// LoadRecords reads a previously saved structured record set from path, for
+// schema-based sources.
+func LoadRecords(path string) ([]extract.Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var records []extract.Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parse state file: %w", err)
+	}
+	return records, nil
+}
+
+// SaveRecords writes a structured record set to path atomically.
+func SaveRecords(path string, records []extract.Record) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeAtomic(path, data)
+}
@@ -0,0 +1,68 @@
+// Package state persists what the tracker has last seen for each source:
+// the extracted value set and the HTTP cache validators used for
+// conditional GET.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/fetcher"
+)
+
+// metaPath returns the cache-metadata sidecar path for a state file, e.g.
+// "last_response.json" -> "last_response.meta.json".
+func metaPath(stateFile string) string {
+	ext := filepath.Ext(stateFile)
+	return stateFile[:len(stateFile)-len(ext)] + ".meta" + ext
+}
+
+// LoadCacheMeta reads the ETag/Last-Modified validators saved alongside
+// stateFile. A missing sidecar is not an error: it just means there's
+// nothing to send conditional-GET headers with yet.
+func LoadCacheMeta(stateFile string) (fetcher.CacheMeta, error) {
+	data, err := os.ReadFile(metaPath(stateFile))
+	if os.IsNotExist(err) {
+		return fetcher.CacheMeta{}, nil
+	}
+	if err != nil {
+		return fetcher.CacheMeta{}, err
+	}
+	var meta fetcher.CacheMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return fetcher.CacheMeta{}, fmt.Errorf("parse cache meta: %w", err)
+	}
+	return meta, nil
+}
+
+// SaveCacheMeta writes the cache validators alongside stateFile atomically,
+// so a crash mid-write can never leave a corrupt baseline.
+func SaveCacheMeta(stateFile string, meta fetcher.CacheMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeAtomic(metaPath(stateFile), data)
+}
+
+// writeAtomic writes data to a temp file in the same directory as path and
+// renames it into place, so readers never observe a partially-written file.
+func writeAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest is the list of sources the tracker should poll, along with
+// defaults shared by all of them.
+type Manifest struct {
+	DefaultChatID string   `json:"default_chat_id,omitempty" yaml:"default_chat_id,omitempty"`
+	Sources       []Source `json:"sources" yaml:"sources"`
+}
+
+// loadManifest reads a manifest file and returns the sources it describes.
+// The file format is chosen by extension: ".opml" is parsed as an OPML
+// watchlist (see opml.go), ".yaml"/".yml" and ".json" are parsed as a plain
+// Manifest document.
+func loadManifest(path string) (*Manifest, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".opml":
+		return loadManifestOPML(path)
+	case ".yaml", ".yml":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read manifest: %w", err)
+		}
+		var m Manifest
+		if err := yaml.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("parse manifest: %w", err)
+		}
+		return &m, nil
+	case ".json":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read manifest: %w", err)
+		}
+		var m Manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("parse manifest: %w", err)
+		}
+		return &m, nil
+	default:
+		return nil, fmt.Errorf("unsupported manifest extension %q (want .opml, .yaml, .yml or .json)", ext)
+	}
+}
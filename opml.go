@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+)
+
+// opmlOutline mirrors the subset of OPML's <outline> element the tracker
+// cares about, plus every attribute verbatim via Attrs. go-opml's Outline
+// type only maps OPML's own named attributes (xmlUrl, title, ...) and has
+// no way to read arbitrary custom ones, but the manifest's trackPath/format/
+// chatId attributes are exactly that, so outlines are decoded directly with
+// encoding/xml instead of going through the library.
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr"`
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Attrs    []xml.Attr    `xml:",any,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Body    struct {
+		Outlines []opmlOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+// loadManifestOPML parses an OPML watchlist exported from a feed reader into
+// a Manifest. Each outline with an `xmlUrl`, at any nesting depth, becomes a
+// Source: its `xmlUrl` attribute is the endpoint, its `text` (falling back to `title`) is the label, and a
+// custom `trackPath` attribute supplies the gjson/CSS selector. Outlines
+// without an `xmlUrl` (folders used for grouping) are skipped.
+func loadManifestOPML(path string) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open opml: %w", err)
+	}
+	defer f.Close()
+
+	var doc opmlDocument
+	if err := xml.NewDecoder(f).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse opml: %w", err)
+	}
+
+	var m Manifest
+	var walk func(outlines []opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, o := range outlines {
+			if o.XMLURL == "" {
+				walk(o.Outlines)
+				continue
+			}
+			label := o.Text
+			if label == "" {
+				label = o.Title
+			}
+			m.Sources = append(m.Sources, Source{
+				Label:     label,
+				Endpoint:  o.XMLURL,
+				TrackPath: outlineAttr(o, "trackPath"),
+				Format:    outlineAttr(o, "format"),
+				ChatID:    outlineAttr(o, "chatId"),
+			})
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Body.Outlines)
+
+	return &m, nil
+}
+
+// outlineAttr reads a non-standard OPML attribute (one go-opml's Outline
+// type doesn't map to a named field) off an outline's raw attribute list.
+func outlineAttr(o opmlOutline, name string) string {
+	for _, a := range o.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
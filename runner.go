@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/extract"
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/fetcher"
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/notify"
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/state"
+)
+
+// buildNotifiers resolves a source's notifier chain. A source with no
+// explicit Notify entries falls back to a single Telegram notifier using
+// its own ChatID, or the manifest-wide default chat.
+func buildNotifiers(src Source, g notify.Globals, defaultChatID string) (notify.Chain, error) {
+	if len(src.Notify) == 0 {
+		return notify.Chain{notify.NewTelegram(g.TelegramToken, src.chatIDOrDefault(defaultChatID))}, nil
+	}
+
+	chain := make(notify.Chain, 0, len(src.Notify))
+	for _, cfg := range src.Notify {
+		if (cfg.Type == "" || cfg.Type == "telegram") && cfg.ChatID == "" {
+			cfg.ChatID = src.chatIDOrDefault(defaultChatID)
+		}
+		n, err := notify.Build(cfg, g)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] notifier config: %w", src.Label, err)
+		}
+		chain = append(chain, n)
+	}
+	return chain, nil
+}
+
+// RunResult summarizes what happened during one runSource call, for callers
+// (the daemon scheduler) that want to record it beyond a plain error.
+type RunResult struct {
+	Changed bool
+	Added   []string
+	Removed []string
+}
+
+// runSource fetches a single source, diffs it against its saved state, and
+// sends a notification (citing the source's label) if anything changed. It
+// reuses the same fetch/extract/diff building blocks as the original
+// single-source flow, now backed by the fetcher/state/notify/extract
+// packages. Extraction always goes through extract.Schema: a bare TrackPath
+// becomes the legacy single-"value"-field schema (see Source.effectiveSchema),
+// so plain added/removed diffs and full structured record diffs share one
+// code path.
+func runSource(ctx context.Context, f *fetcher.Fetcher, g notify.Globals, defaultChatID string, src Source) (RunResult, error) {
+	notifiers, err := buildNotifiers(src, g, defaultChatID)
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	notifyNote := func(note string) {
+		change := notify.Change{Label: src.Label, Endpoint: src.Endpoint, Note: note, Timestamp: time.Now()}
+		if err := notifiers.Send(ctx, change); err != nil {
+			log.Printf("[%s] notify failed: %v", src.Label, err)
+		}
+	}
+
+	stateFile := src.StateFile()
+
+	cache, err := state.LoadCacheMeta(stateFile)
+	if err != nil {
+		log.Printf("[%s] warning: loading cache metadata: %v", src.Label, err)
+	}
+
+	body, contentType, notModified, newCache, err := f.Do(ctx, src.Endpoint, src.Headers, cache)
+	if err != nil {
+		notifyNote(fmt.Sprintf("fetch failed: %v", err))
+		return RunResult{}, fmt.Errorf("[%s] fetch failed: %w", src.Label, err)
+	}
+
+	var schema extract.Schema
+	var newRecords []extract.Record
+	if notModified {
+		newRecords, err = state.LoadRecords(stateFile)
+		if err != nil {
+			return RunResult{}, fmt.Errorf("[%s] 304 Not Modified but no saved state to reuse: %w", src.Label, err)
+		}
+		log.Printf("[%s] not modified, reusing saved state.", src.Label)
+	} else {
+		format := src.Format
+		if format == "" {
+			format = detectFormat(contentType)
+		}
+		schema = src.effectiveSchema(format)
+		switch format {
+		case "html":
+			newRecords, err = extract.HTML(body, schema)
+			if err != nil {
+				notifyNote(fmt.Sprintf("html extraction failed: %v", err))
+				return RunResult{}, fmt.Errorf("[%s] html extraction failed: %w", src.Label, err)
+			}
+		default:
+			newRecords = extract.JSON(body, schema)
+		}
+		if len(newRecords) == 0 {
+			log.Printf("[%s] warning: schema matched 0 records", src.Label)
+		}
+	}
+
+	var result RunResult
+	oldRecords, err := state.LoadRecords(stateFile)
+	if err != nil {
+		log.Printf("[%s] first run, saving baseline.", src.Label)
+		notifyNote("first run, saving baseline.")
+	} else if !notModified {
+		added, removed, modified := extract.Diff(schema, oldRecords, newRecords)
+		if len(added) > 0 || len(removed) > 0 || len(modified) > 0 {
+			change := notify.Change{
+				Label: src.Label, Endpoint: src.Endpoint,
+				Added: formatRecords(schema, added), Removed: formatRecords(schema, removed),
+				Modified: toFieldChanges(modified), Timestamp: time.Now(),
+			}
+			if err := notifiers.Send(ctx, change); err != nil {
+				log.Printf("[%s] notify failed: %v", src.Label, err)
+			}
+			log.Printf("[%s] change detected, notification sent.", src.Label)
+			result = RunResult{Changed: true, Added: change.Added, Removed: change.Removed}
+		} else {
+			log.Printf("[%s] no change.", src.Label)
+		}
+	}
+
+	if !notModified {
+		if err := state.SaveRecords(stateFile, newRecords); err != nil {
+			return RunResult{}, fmt.Errorf("[%s] save state: %w", src.Label, err)
+		}
+	}
+	if err := state.SaveCacheMeta(stateFile, newCache); err != nil {
+		return RunResult{}, fmt.Errorf("[%s] save cache metadata: %w", src.Label, err)
+	}
+	return result, nil
+}
+
+// formatRecords renders records for display in a notification: a legacy
+// single-"value"-field record shows just its value, while a multi-field
+// record shows "key: field=val, field=val".
+func formatRecords(schema extract.Schema, records []extract.Record) []string {
+	out := make([]string, len(records))
+	for i, r := range records {
+		if v, ok := r["value"]; ok && len(r) == 1 {
+			out[i] = v
+			continue
+		}
+		fields := make([]string, 0, len(r))
+		for k, v := range r {
+			fields = append(fields, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(fields)
+		out[i] = fmt.Sprintf("%s: %s", schema.KeyOf(r), strings.Join(fields, ", "))
+	}
+	return out
+}
+
+func toFieldChanges(modified []extract.FieldChange) []notify.FieldChange {
+	out := make([]notify.FieldChange, len(modified))
+	for i, fc := range modified {
+		out[i] = notify.FieldChange{Key: fc.Key, Field: fc.Field, Before: fc.Before, After: fc.After}
+	}
+	return out
+}
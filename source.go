@@ -0,0 +1,93 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/extract"
+	"github.com/PPrydorozhnyi/json-state-tracker/internal/notify"
+)
+
+// Source is a single thing to watch: an endpoint, how to extract values from
+// it, and where to send a notification when those values change. Sources are
+// loaded from a Manifest (see manifest.go) instead of the legacy
+// TARGET_ENDPOINT/TRACK_PATH env vars.
+type Source struct {
+	Label     string            `json:"label" yaml:"label"`
+	Endpoint  string            `json:"endpoint" yaml:"endpoint"`
+	Headers   map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	TrackPath string            `json:"track_path" yaml:"track_path"`
+	Format    string            `json:"format,omitempty" yaml:"format,omitempty"` // "json" or "html"; empty = auto-detect
+
+	// Schema, when set, switches extraction into structured-record mode:
+	// TrackPath is ignored and Schema.Root/Parent plus Schema.Fields govern
+	// what's pulled out of each item. Diffs then report per-field
+	// modifications in addition to added/removed records.
+	Schema *extract.Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+
+	// ChatID overrides the manifest's default Telegram chat; only used when
+	// Notify is empty or contains a telegram entry without its own chat_id.
+	ChatID string `json:"chat_id,omitempty" yaml:"chat_id,omitempty"`
+	// Notify is the chain of notifiers to run when this source changes. If
+	// empty, the source falls back to a single Telegram notifier using
+	// ChatID (or the manifest-wide default chat).
+	Notify []notify.Config `json:"notify,omitempty" yaml:"notify,omitempty"`
+
+	// Interval overrides the daemon's default poll interval for this source
+	// (e.g. "5m"). Ignored in --once mode.
+	Interval string `json:"interval,omitempty" yaml:"interval,omitempty"`
+}
+
+// pollInterval parses Interval, falling back to def if it's unset or
+// malformed.
+func (s Source) pollInterval(def time.Duration) time.Duration {
+	if s.Interval == "" {
+		return def
+	}
+	d, err := time.ParseDuration(s.Interval)
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
+
+// Key returns a stable identifier for the source, derived from its endpoint
+// and label, suitable for naming its per-source state file. It is stable
+// across runs as long as the endpoint and label don't change.
+func (s Source) Key() string {
+	h := sha1.New()
+	h.Write([]byte(s.Endpoint))
+	h.Write([]byte{0})
+	h.Write([]byte(s.Label))
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// StateFile returns the path of the per-source state file used to persist
+// the last-seen set of values.
+func (s Source) StateFile() string {
+	return fmt.Sprintf("last_response.%s.json", s.Key())
+}
+
+// chatIDOrDefault returns the source's own ChatID if set, falling back to
+// the manifest-wide default chat.
+func (s Source) chatIDOrDefault(def string) string {
+	if s.ChatID != "" {
+		return s.ChatID
+	}
+	return def
+}
+
+// effectiveSchema returns the source's explicit Schema, or the legacy
+// single-"value"-field schema built from TrackPath if none was given.
+func (s Source) effectiveSchema(format string) extract.Schema {
+	if s.Schema != nil {
+		return *s.Schema
+	}
+	if format == "html" {
+		selector, attr := parseHTMLPath(s.TrackPath)
+		return extract.NewLegacyHTMLSchema(selector, attr)
+	}
+	return extract.NewLegacyJSONSchema(s.TrackPath)
+}
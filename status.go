@@ -0,0 +1,65 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// sourceStatus is the latest known state of one source, read by the
+// /healthz and /metrics endpoints.
+type sourceStatus struct {
+	Label       string
+	Endpoint    string
+	LastRun     time.Time
+	LastError   string
+	ChangeCount int
+}
+
+// statusRegistry tracks the latest sourceStatus for every source in a
+// manifest, keyed by Source.Key().
+type statusRegistry struct {
+	mu       sync.RWMutex
+	statuses map[string]*sourceStatus
+}
+
+func newStatusRegistry(sources []Source) *statusRegistry {
+	r := &statusRegistry{statuses: make(map[string]*sourceStatus, len(sources))}
+	for _, src := range sources {
+		r.statuses[src.Key()] = &sourceStatus{Label: src.Label, Endpoint: src.Endpoint}
+	}
+	return r
+}
+
+// record updates a source's status after a scheduler run.
+func (r *statusRegistry) record(src Source, result RunResult, runErr error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.statuses[src.Key()]
+	if !ok {
+		s = &sourceStatus{Label: src.Label, Endpoint: src.Endpoint}
+		r.statuses[src.Key()] = s
+	}
+	s.LastRun = time.Now()
+	if runErr != nil {
+		s.LastError = runErr.Error()
+	} else {
+		s.LastError = ""
+	}
+	if result.Changed {
+		s.ChangeCount++
+	}
+}
+
+// snapshot returns a copy of every tracked status, safe to read without
+// holding the registry's lock.
+func (r *statusRegistry) snapshot() []sourceStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]sourceStatus, 0, len(r.statuses))
+	for _, s := range r.statuses {
+		out = append(out, *s)
+	}
+	return out
+}